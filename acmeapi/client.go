@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+
+package acmeapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+)
+
+// Client speaks ACME v2 (RFC 8555) against any compliant CA (Let's
+// Encrypt, ZeroSSL, smallstep, DigiCert's ACME endpoint, ...) and
+// satisfies digicertapi.CertAuthority so certificate resources can pick
+// it as an alternative to the DigiCert REST API.
+type Client struct {
+	acme        *acme.Client
+	account     *acme.Account
+	dnsProvider dns.DNSProvider
+}
+
+// Config configures the ACME account used for issuance.
+type Config struct {
+	DirectoryURL string
+	// EABKeyID and EABHMACKey configure External Account Binding, required
+	// by CAs (ZeroSSL, DigiCert's ACME endpoint) that tie ACME accounts to
+	// a pre-existing customer account. EABHMACKey is base64url encoded.
+	EABKeyID   string
+	EABHMACKey string
+	// DNSProvider dispatches the DNS-01 challenge TXT record.
+	DNSProvider dns.DNSProvider
+}
+
+func NewClient(config Config) (*Client, error) {
+	if config.DirectoryURL == "" {
+		return nil, fmt.Errorf("acmeapi.NewClient(): missing directory_url")
+	}
+	if config.DNSProvider == nil {
+		return nil, fmt.Errorf("acmeapi.NewClient(): missing dns_provider")
+	}
+
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	acmeClient := &acme.Client{
+		DirectoryURL: config.DirectoryURL,
+		Key:          accountKey,
+	}
+
+	account := &acme.Account{}
+	if config.EABKeyID != "" {
+		hmacKey, err := base64.RawURLEncoding.DecodeString(config.EABHMACKey)
+		if err != nil {
+			return nil, fmt.Errorf("acmeapi.NewClient(): invalid eab_hmac_key: %w", err)
+		}
+		account.ExternalAccountBinding = &acme.ExternalAccountBinding{
+			KID: config.EABKeyID,
+			Key: hmacKey,
+		}
+	}
+
+	account, err = acmeClient.Register(context.Background(), account, acme.AcceptTOS)
+	if err != nil {
+		return nil, fmt.Errorf("acmeapi.NewClient(): failed to register ACME account: %w", err)
+	}
+
+	return &Client{
+		acme:        acmeClient,
+		account:     account,
+		dnsProvider: config.DNSProvider,
+	}, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+
+package acmeapi
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	digicertapi "github.com/myklst/terraform-provider-st-digicert/digicertAPI"
+)
+
+const (
+	pollInterval = 5 * time.Second
+	pollTimeout  = 10 * time.Minute
+)
+
+// IssueCert satisfies digicertapi.CertAuthority. It creates an order for
+// orderPayload.Certificate.DNSNames, dispatches a DNS-01 challenge for
+// every SAN through the configured dns.DNSProvider, waits for the order to
+// become ready, finalizes it with the caller-supplied CSR and downloads
+// the issued chain.
+func (c *Client) IssueCert(orderPayload digicertapi.OrderPayload) (digicertapi.IssueCertRespBody, error) {
+	ctx := context.Background()
+
+	authzURLs := make([]string, 0, len(orderPayload.Certificate.DNSNames))
+	order, err := c.acme.AuthorizeOrder(ctx, acme.DomainIDs(orderPayload.Certificate.DNSNames...))
+	if err != nil {
+		return digicertapi.IssueCertRespBody{}, fmt.Errorf("acmeapi: failed to create order: %w", err)
+	}
+	authzURLs = append(authzURLs, order.AuthzURLs...)
+
+	for _, authzURL := range authzURLs {
+		if err := c.completeDNS01(ctx, authzURL); err != nil {
+			return digicertapi.IssueCertRespBody{}, err
+		}
+	}
+
+	order, err = c.acme.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return digicertapi.IssueCertRespBody{}, fmt.Errorf("acmeapi: order did not become ready: %w", err)
+	}
+
+	// orderPayload.Certificate.CSR is PEM, as produced by BuildCSR and
+	// expected by the DigiCert REST API, but CreateOrderCert requires the
+	// raw ASN.1 DER bytes.
+	block, _ := pem.Decode([]byte(orderPayload.Certificate.CSR))
+	if block == nil {
+		return digicertapi.IssueCertRespBody{}, fmt.Errorf("acmeapi: certificate.csr is not a valid PEM-encoded CSR")
+	}
+
+	der, _, err := c.acme.CreateOrderCert(ctx, order.FinalizeURL, block.Bytes, true)
+	if err != nil {
+		return digicertapi.IssueCertRespBody{}, fmt.Errorf("acmeapi: failed to finalize order: %w", err)
+	}
+
+	chain, err := pemChain(der)
+	if err != nil {
+		return digicertapi.IssueCertRespBody{}, err
+	}
+
+	return digicertapi.IssueCertRespBody{
+		SubjectCommonName: orderPayload.Certificate.CommonName,
+		CertificateChain:  chain,
+	}, nil
+}
+
+// ReissueCert re-runs the full issuance flow, since ACME has no concept of
+// reissuing an existing order.
+func (c *Client) ReissueCert(orderPayload digicertapi.OrderPayload, orderID int) (digicertapi.IssueCertRespBody, error) {
+	return c.IssueCert(orderPayload)
+}
+
+// RevokeCert satisfies digicertapi.CertAuthority. ACME identifies
+// certificates by their DER bytes rather than a numeric ID, so callers on
+// this backend should route revocation through RevokeCertPEM instead;
+// this exists only to keep Client assignable to the shared interface.
+func (c *Client) RevokeCert(certID int) error {
+	return fmt.Errorf("acmeapi: RevokeCert requires the certificate PEM, use RevokeCertPEM")
+}
+
+// RevokeCertPEM revokes a certificate previously issued through IssueCert,
+// given its PEM encoding (the first PEM block is the one revoked).
+func (c *Client) RevokeCertPEM(certPEM []byte) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("acmeapi: certificate is not valid PEM")
+	}
+	return c.acme.RevokeCert(context.Background(), nil, block.Bytes, acme.CRLReasonUnspecified)
+}
+
+// completeDNS01 fetches the DNS-01 challenge for authzURL, publishes the
+// verification TXT record through the configured DNSProvider, accepts the
+// challenge and polls the authorization until the CA reports it valid.
+func (c *Client) completeDNS01(ctx context.Context, authzURL string) error {
+	authz, err := c.acme.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("acmeapi: failed to fetch authorization: %w", err)
+	}
+
+	var challenge *acme.Challenge
+	for _, ch := range authz.Challenges {
+		if ch.Type == "dns-01" {
+			challenge = ch
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("acmeapi: no dns-01 challenge offered for %s", authz.Identifier.Value)
+	}
+
+	token, err := c.acme.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return fmt.Errorf("acmeapi: failed to compute dns-01 key authorization: %w", err)
+	}
+
+	recordID, err := c.dnsProvider.CreateVerificationRecord(authz.Identifier.Value, token)
+	if err != nil {
+		return fmt.Errorf("acmeapi: failed to publish dns-01 record for %s: %w", authz.Identifier.Value, err)
+	}
+	defer c.dnsProvider.DeleteDnsRecord(recordID, "TXT")
+
+	if _, err := c.acme.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("acmeapi: failed to accept dns-01 challenge: %w", err)
+	}
+
+	deadline := time.Now().Add(pollTimeout)
+	for {
+		authz, err = c.acme.GetAuthorization(ctx, authzURL)
+		if err != nil {
+			return fmt.Errorf("acmeapi: failed to poll authorization: %w", err)
+		}
+		switch authz.Status {
+		case acme.StatusValid:
+			return nil
+		case acme.StatusInvalid:
+			return fmt.Errorf("acmeapi: authorization for %s failed", authz.Identifier.Value)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("acmeapi: timed out waiting for authorization of %s", authz.Identifier.Value)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func pemChain(der [][]byte) (chain []digicertapi.CertificateChain, err error) {
+	for _, cert := range der {
+		chain = append(chain, digicertapi.CertificateChain{
+			Pem: string(pemEncode("CERTIFICATE", cert)),
+		})
+	}
+	return chain, nil
+}
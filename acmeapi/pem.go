@@ -0,0 +1,14 @@
+// Copyright (c) HashiCorp, Inc.
+
+package acmeapi
+
+import (
+	"bytes"
+	"encoding/pem"
+)
+
+func pemEncode(blockType string, der []byte) []byte {
+	buf := &bytes.Buffer{}
+	pem.Encode(buf, &pem.Block{Type: blockType, Bytes: der})
+	return buf.Bytes()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+
+package alicloud
+
+import (
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+	alicloudDns "github.com/myklst/terraform-provider-st-digicert/digicert/dns/platform/alicloud/dns"
+)
+
+func init() {
+	dns.Register("alicloud", newProvider)
+}
+
+// provider adapts *alicloudDns.Alidns to the dns.DNSProvider interface so it
+// can be selected through a certificate resource's dns_provider block.
+type provider struct {
+	client *alicloudDns.Alidns
+}
+
+func newProvider(config map[string]string) (dns.DNSProvider, error) {
+	client, err := alicloudDns.NewClient(config["access_key"], config["secret_key"])
+	if err != nil {
+		return nil, err
+	}
+	return &provider{client: client}, nil
+}
+
+func (p *provider) GetAllDnsRecords(domain string) (records []dns.Record, err error) {
+	domainRecords, err := p.client.GetAllDnsRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range domainRecords {
+		records = append(records, dns.Record{
+			ID:    *r.RecordId,
+			Name:  *r.RR,
+			Type:  *r.Type,
+			Value: *r.Value,
+		})
+	}
+	return records, nil
+}
+
+func (p *provider) AddDnsRecord(domain, rrType, rr, value string) (recordID string, err error) {
+	return p.client.AddDnsRecord(domain, rrType, rr, value)
+}
+
+func (p *provider) UpdateDnsRecord(id, rrType, subdomain, value string) (err error) {
+	return p.client.UpdateDnsRecord(id, rrType, subdomain, value)
+}
+
+// Alidns records carry a real RecordId, so rrType is unused; it is only
+// part of the signature to satisfy dns.DNSProvider for backends that need
+// it (Route53, Cloud DNS).
+func (p *provider) DeleteDnsRecord(id, rrType string) (err error) {
+	return p.client.DeleteDnsRecord(id)
+}
+
+func (p *provider) CreateVerificationRecord(commonName, token string) (recordID string, err error) {
+	return p.client.CreateAliDNSRecord(commonName, token)
+}
@@ -5,6 +5,7 @@ package dns
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	alidns "github.com/alibabacloud-go/alidns-20150109/v2/client"
@@ -21,22 +22,89 @@ type Alidns struct {
 
 const (
 	MAX_ELAPSED_TIME = 10 * time.Minute
+	PAGE_SIZE        = int64(500) // AliCloud allows at most 500 records per page.
+	// MAX_CONCURRENT_PAGES bounds how many DescribeDomainRecords pages are
+	// fetched in parallel once the total page count is known, so large
+	// zones don't trip Alicloud's per-second QPS limit.
+	MAX_CONCURRENT_PAGES = 5
 )
 
 func (a *Alidns) GetAllDnsRecords(domain string) (domainRecords []*alidns.DescribeDomainRecordsResponseBodyDomainRecordsRecord, err error) {
+	firstPage, totalCount, err := a.describeDomainRecordsPage(domain, 1)
+	if err != nil {
+		return nil, err
+	}
+	domainRecords = append(domainRecords, firstPage...)
+
+	totalPages := (totalCount + int(PAGE_SIZE) - 1) / int(PAGE_SIZE)
+	if totalPages <= 1 {
+		return domainRecords, nil
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sem      = make(chan struct{}, MAX_CONCURRENT_PAGES)
+	)
+
+	for page := 2; page <= totalPages; page++ {
+		page := page
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			records, _, pageErr := a.describeDomainRecordsPage(domain, page)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if pageErr != nil {
+				if firstErr == nil {
+					firstErr = pageErr
+				}
+				return
+			}
+			domainRecords = append(domainRecords, records...)
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return domainRecords, nil
+}
+
+// describeDomainRecordsPage fetches a single page of records, retrying
+// throttling errors with jittered exponential backoff while treating
+// auth/permission errors as permanent (non-retryable).
+func (a *Alidns) describeDomainRecordsPage(domain string, pageNumber int) (records []*alidns.DescribeDomainRecordsResponseBodyDomainRecordsRecord, totalCount int, err error) {
 	describeDomainRecordsRequest := &alidns.DescribeDomainRecordsRequest{
 		DomainName: tea.String(domain),
-		PageSize:   tea.Int64(500), // AliCloud maximum allow 500 records. It's
-		// quiet a lot for now, so we don't do paging
-		// process first.
+		PageSize:   tea.Int64(PAGE_SIZE),
+		PageNumber: tea.Int64(int64(pageNumber)),
 	}
 
-	response, err := a.Client.DescribeDomainRecords(describeDomainRecordsRequest)
-	if err != nil {
-		return nil, err
+	var response *alidns.DescribeDomainRecordsResponse
+	describePage := func() error {
+		var describeErr error
+		response, describeErr = a.Client.DescribeDomainRecords(describeDomainRecordsRequest)
+		if describeErr != nil {
+			tflog.Debug(context.Background(), fmt.Sprintf("Alidns DescribeDomainRecords page %d error: %s", pageNumber, describeErr.Error()))
+			if alicloud.IsPermanentCommonError(describeErr.Error()) {
+				return backoff.Permanent(describeErr)
+			}
+			return describeErr
+		}
+		return nil
+	}
+	if err := backoff_retry.RetryOperator(describePage, MAX_ELAPSED_TIME); err != nil {
+		return nil, 0, fmt.Errorf("Alidns describe dns records. Failed to list dns records on page %d: %v", pageNumber, err)
 	}
 
-	return response.Body.DomainRecords.Record, err
+	return response.Body.DomainRecords.Record, int(tea.Int64Value(response.Body.TotalCount)), nil
 }
 
 func (a *Alidns) AddDnsRecord(domain, rrType, rr, value string) (recordID string, err error) {
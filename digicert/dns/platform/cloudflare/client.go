@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+
+package cloudflare
+
+import (
+	"fmt"
+
+	cf "github.com/cloudflare/cloudflare-go"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+)
+
+func init() {
+	dns.Register("cloudflare", newProvider)
+}
+
+// Cloudflare implements dns.DNSProvider against a single Cloudflare zone.
+type Cloudflare struct {
+	Client *cf.API
+	ZoneID string
+}
+
+func newProvider(config map[string]string) (dns.DNSProvider, error) {
+	return NewClient(config["api_token"], config["zone_id"])
+}
+
+func NewClient(apiToken, zoneID string) (*Cloudflare, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("cloudflare.NewClient(): missing api_token")
+	}
+	if zoneID == "" {
+		return nil, fmt.Errorf("cloudflare.NewClient(): missing zone_id")
+	}
+
+	client, err := cf.NewWithAPIToken(apiToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cloudflare{
+		Client: client,
+		ZoneID: zoneID,
+	}, nil
+}
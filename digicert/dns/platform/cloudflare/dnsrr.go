@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+
+	cf "github.com/cloudflare/cloudflare-go"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+)
+
+func (c *Cloudflare) GetAllDnsRecords(domain string) (records []dns.Record, err error) {
+	zoneID := cf.ZoneIdentifier(c.ZoneID)
+	dnsRecords, _, err := c.Client.ListDNSRecords(context.Background(), zoneID, cf.ListDNSRecordsParams{Name: domain})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range dnsRecords {
+		records = append(records, dns.Record{
+			ID:    r.ID,
+			Name:  r.Name,
+			Type:  r.Type,
+			Value: r.Content,
+		})
+	}
+	return records, nil
+}
+
+func (c *Cloudflare) AddDnsRecord(domain, rrType, rr, value string) (recordID string, err error) {
+	zoneID := cf.ZoneIdentifier(c.ZoneID)
+	record, err := c.Client.CreateDNSRecord(context.Background(), zoneID, cf.CreateDNSRecordParams{
+		Type:    rrType,
+		Name:    dns.RecordName(domain, rr),
+		Content: value,
+	})
+	if err != nil {
+		return "", err
+	}
+	return record.ID, nil
+}
+
+func (c *Cloudflare) UpdateDnsRecord(id, rrType, subdomain, value string) (err error) {
+	zoneID := cf.ZoneIdentifier(c.ZoneID)
+	_, err = c.Client.UpdateDNSRecord(context.Background(), zoneID, cf.UpdateDNSRecordParams{
+		ID:      id,
+		Type:    rrType,
+		Content: value,
+	})
+	return err
+}
+
+// Cloudflare records carry a real ID, so rrType is unused; it is only part
+// of the signature to satisfy dns.DNSProvider for backends that need it
+// (Route53, Cloud DNS).
+func (c *Cloudflare) DeleteDnsRecord(id, rrType string) (err error) {
+	zoneID := cf.ZoneIdentifier(c.ZoneID)
+	return c.Client.DeleteDNSRecord(context.Background(), zoneID, id)
+}
+
+// CreateVerificationRecord updates the existing apex TXT record for
+// commonName in place if one already exists, rather than always adding a
+// new one; otherwise a re-apply (e.g. a subsequent DCV attempt) would pile
+// up duplicate TXT records instead of replacing the challenge value.
+func (c *Cloudflare) CreateVerificationRecord(commonName, token string) (recordID string, err error) {
+	records, err := c.GetAllDnsRecords(commonName)
+	if err != nil {
+		return "", fmt.Errorf("cloudflare create dns record. Failed to list existing records: %v", err)
+	}
+
+	for _, r := range records {
+		if r.Name == commonName && r.Type == "TXT" {
+			if err := c.UpdateDnsRecord(r.ID, "TXT", "@", token); err != nil {
+				return "", fmt.Errorf("cloudflare create dns record. Failed to update verification TXT record: %v", err)
+			}
+			return r.ID, nil
+		}
+	}
+
+	recordID, err = c.AddDnsRecord(commonName, "TXT", "@", token)
+	if err != nil {
+		return "", fmt.Errorf("cloudflare create dns record. Failed to create verification TXT record: %v", err)
+	}
+	return recordID, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+
+package gcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+	googledns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+)
+
+func init() {
+	dns.Register("gcloud", newProvider)
+}
+
+// CloudDNS implements dns.DNSProvider against a GCP Cloud DNS managed zone.
+type CloudDNS struct {
+	Service     *googledns.Service
+	Project     string
+	ManagedZone string
+}
+
+func newProvider(config map[string]string) (dns.DNSProvider, error) {
+	return NewClient(config["credentials_json"], config["project"], config["managed_zone"])
+}
+
+func NewClient(credentialsJSON, project, managedZone string) (*CloudDNS, error) {
+	if credentialsJSON == "" {
+		return nil, fmt.Errorf("gcloud.NewClient(): missing credentials_json")
+	}
+	if project == "" {
+		return nil, fmt.Errorf("gcloud.NewClient(): missing project")
+	}
+	if managedZone == "" {
+		return nil, fmt.Errorf("gcloud.NewClient(): missing managed_zone")
+	}
+
+	service, err := googledns.NewService(context.Background(), option.WithCredentialsJSON([]byte(credentialsJSON)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudDNS{
+		Service:     service,
+		Project:     project,
+		ManagedZone: managedZone,
+	}, nil
+}
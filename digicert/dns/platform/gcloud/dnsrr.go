@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+
+package gcloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+	googledns "google.golang.org/api/dns/v1"
+)
+
+func (g *CloudDNS) GetAllDnsRecords(domain string) (records []dns.Record, err error) {
+	resp, err := g.Service.ResourceRecordSets.List(g.Project, g.ManagedZone).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rrset := range resp.Rrsets {
+		for _, rrd := range rrset.Rrdatas {
+			records = append(records, dns.Record{
+				Name:  strings.TrimSuffix(rrset.Name, "."),
+				Type:  rrset.Type,
+				Value: rrd,
+			})
+		}
+	}
+	return records, nil
+}
+
+func (g *CloudDNS) AddDnsRecord(domain, rrType, rr, value string) (recordID string, err error) {
+	name := dns.RecordName(domain, rr)
+	change := &googledns.Change{
+		Additions: []*googledns.ResourceRecordSet{
+			{
+				Name:    name,
+				Type:    rrType,
+				Ttl:     300,
+				Rrdatas: []string{value},
+			},
+		},
+	}
+
+	if _, err := g.Service.Changes.Create(g.Project, g.ManagedZone, change).Do(); err != nil {
+		return "", err
+	}
+
+	// Cloud DNS has no record ID; the name/type pair is the identity
+	// callers use for update and delete.
+	return name, nil
+}
+
+func (g *CloudDNS) UpdateDnsRecord(id, rrType, subdomain, value string) (err error) {
+	existing, err := g.Service.ResourceRecordSets.Get(g.Project, g.ManagedZone, id, rrType).Do()
+	if err != nil {
+		return err
+	}
+
+	change := &googledns.Change{
+		Deletions: []*googledns.ResourceRecordSet{existing},
+		Additions: []*googledns.ResourceRecordSet{
+			{
+				Name:    id,
+				Type:    rrType,
+				Ttl:     existing.Ttl,
+				Rrdatas: []string{value},
+			},
+		},
+	}
+	_, err = g.Service.Changes.Create(g.Project, g.ManagedZone, change).Do()
+	return err
+}
+
+// DeleteDnsRecord requires rrType because Cloud DNS has no record-ID
+// concept (AddDnsRecord returns the record name as id) and guessing the
+// type could delete an unrelated record sharing that name.
+func (g *CloudDNS) DeleteDnsRecord(id, rrType string) (err error) {
+	existing, err := g.Service.ResourceRecordSets.Get(g.Project, g.ManagedZone, id, rrType).Do()
+	if err != nil {
+		return err
+	}
+
+	change := &googledns.Change{
+		Deletions: []*googledns.ResourceRecordSet{existing},
+	}
+	_, err = g.Service.Changes.Create(g.Project, g.ManagedZone, change).Do()
+	return err
+}
+
+// CreateVerificationRecord replaces any existing apex TXT record for
+// commonName with the new challenge value in a single Change (deletion and
+// addition batched together), since Changes.Create is not an upsert and a
+// second apply would otherwise fail with "already exists".
+func (g *CloudDNS) CreateVerificationRecord(commonName, token string) (recordID string, err error) {
+	name := dns.RecordName(commonName, "@")
+	value := fmt.Sprintf("%q", token)
+
+	change := &googledns.Change{
+		Additions: []*googledns.ResourceRecordSet{
+			{
+				Name:    name,
+				Type:    "TXT",
+				Ttl:     300,
+				Rrdatas: []string{value},
+			},
+		},
+	}
+
+	if existing, err := g.Service.ResourceRecordSets.Get(g.Project, g.ManagedZone, name, "TXT").Do(); err == nil {
+		change.Deletions = []*googledns.ResourceRecordSet{existing}
+	}
+
+	if _, err := g.Service.Changes.Create(g.Project, g.ManagedZone, change).Do(); err != nil {
+		return "", fmt.Errorf("gcloud create dns record. Failed to create verification TXT record: %v", err)
+	}
+
+	// Cloud DNS has no record ID; the name/type pair is the identity
+	// callers use for update and delete.
+	return name, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+
+package route53
+
+import (
+	"context"
+	"fmt"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+)
+
+func init() {
+	dns.Register("route53", newProvider)
+}
+
+// Route53 implements dns.DNSProvider against AWS Route53 hosted zones.
+type Route53 struct {
+	Client       *route53.Client
+	HostedZoneID string
+}
+
+func newProvider(config map[string]string) (dns.DNSProvider, error) {
+	return NewClient(config["access_key_id"], config["secret_access_key"], config["region"], config["hosted_zone_id"])
+}
+
+func NewClient(accessKeyID, secretAccessKey, region, hostedZoneID string) (*Route53, error) {
+	if accessKeyID == "" {
+		return nil, fmt.Errorf("route53.NewClient(): missing access_key_id")
+	}
+	if secretAccessKey == "" {
+		return nil, fmt.Errorf("route53.NewClient(): missing secret_access_key")
+	}
+	if hostedZoneID == "" {
+		return nil, fmt.Errorf("route53.NewClient(): missing hosted_zone_id")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+		awsconfig.WithRegion(region),
+		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Route53{
+		Client:       route53.NewFromConfig(cfg),
+		HostedZoneID: hostedZoneID,
+	}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+
+package route53
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+)
+
+func (r *Route53) GetAllDnsRecords(domain string) (records []dns.Record, err error) {
+	output, err := r.Client.ListResourceRecordSets(context.Background(), &r53types.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(r.HostedZoneID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rrset := range output.ResourceRecordSets {
+		for _, rr := range rrset.ResourceRecords {
+			records = append(records, dns.Record{
+				Name:  strings.TrimSuffix(*rrset.Name, "."),
+				Type:  string(rrset.Type),
+				Value: *rr.Value,
+			})
+		}
+	}
+	return records, nil
+}
+
+func (r *Route53) AddDnsRecord(domain, rrType, rr, value string) (recordID string, err error) {
+	name := dns.RecordName(domain, rr)
+	_, err = r.Client.ChangeResourceRecordSets(context.Background(), &r53types.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(r.HostedZoneID),
+		ChangeBatch: &r53types.ChangeBatch{
+			Changes: []r53types.Change{
+				{
+					Action: r53types.ChangeActionUpsert,
+					ResourceRecordSet: &r53types.ResourceRecordSet{
+						Name: aws.String(name),
+						Type: r53types.RRType(rrType),
+						TTL:  aws.Int64(300),
+						ResourceRecords: []r53types.ResourceRecord{
+							{Value: aws.String(value)},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Route53 has no notion of a record ID; the name/type pair is the
+	// identity callers use for update and delete.
+	return name, nil
+}
+
+func (r *Route53) UpdateDnsRecord(id, rrType, subdomain, value string) (err error) {
+	_, err = r.Client.ChangeResourceRecordSets(context.Background(), &r53types.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(r.HostedZoneID),
+		ChangeBatch: &r53types.ChangeBatch{
+			Changes: []r53types.Change{
+				{
+					Action: r53types.ChangeActionUpsert,
+					ResourceRecordSet: &r53types.ResourceRecordSet{
+						Name: aws.String(id),
+						Type: r53types.RRType(rrType),
+						TTL:  aws.Int64(300),
+						ResourceRecords: []r53types.ResourceRecord{
+							{Value: aws.String(value)},
+						},
+					},
+				},
+			},
+		},
+	})
+	return err
+}
+
+// DeleteDnsRecord requires rrType because Route53 has no record-ID concept
+// (AddDnsRecord returns the record name as id) and guessing the type could
+// delete an unrelated record sharing that name.
+func (r *Route53) DeleteDnsRecord(id, rrType string) (err error) {
+	_, err = r.Client.ChangeResourceRecordSets(context.Background(), &r53types.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(r.HostedZoneID),
+		ChangeBatch: &r53types.ChangeBatch{
+			Changes: []r53types.Change{
+				{
+					Action: r53types.ChangeActionDelete,
+					ResourceRecordSet: &r53types.ResourceRecordSet{
+						Name: aws.String(id),
+						Type: r53types.RRType(rrType),
+					},
+				},
+			},
+		},
+	})
+	return err
+}
+
+func (r *Route53) CreateVerificationRecord(commonName, token string) (recordID string, err error) {
+	recordID, err = r.AddDnsRecord(commonName, "TXT", "@", fmt.Sprintf("%q", token))
+	if err != nil {
+		return "", fmt.Errorf("route53 create dns record. Failed to create verification TXT record: %v", err)
+	}
+	return recordID, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+// DefaultPropagationTimeout is used when the resource's
+// dns_propagation_timeout attribute is left unset.
+const DefaultPropagationTimeout = 2 * time.Minute
+
+const propagationPollInterval = 5 * time.Second
+
+// WaitForDNSPropagation blocks until every authoritative nameserver for
+// domain answers a direct TXT query with token, or timeout elapses. Call
+// this between publishing a verification record (CreateVerificationRecord)
+// and asking the CA to validate it, since the CA's resolver may otherwise
+// query before the record has propagated to every authoritative server.
+func WaitForDNSPropagation(domain, token string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultPropagationTimeout
+	}
+
+	nameservers, err := authoritativeNameservers(domain)
+	if err != nil {
+		return fmt.Errorf("dns.WaitForDNSPropagation(): failed to resolve authoritative nameservers for %s: %w", domain, err)
+	}
+	if len(nameservers) == 0 {
+		return fmt.Errorf("dns.WaitForDNSPropagation(): no authoritative nameservers found for %s", domain)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if allNameserversHaveToken(nameservers, domain, token) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("dns.WaitForDNSPropagation(): timed out after %s waiting for TXT record on %s to propagate", timeout, domain)
+		}
+		time.Sleep(propagationPollInterval)
+	}
+}
+
+func allNameserversHaveToken(nameservers []string, domain, token string) bool {
+	for _, ns := range nameservers {
+		ok, err := queryTXTRecord(ns, domain, token)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// authoritativeNameservers resolves the nameservers for domain's zone
+// apex. net.LookupNS only returns useful answers when asked about the
+// apex itself, so a certificate for a subdomain like "www.example.com"
+// first needs its apex ("example.com") located via findZoneApex.
+func authoritativeNameservers(domain string) (nameservers []string, err error) {
+	apex, err := findZoneApex(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := net.LookupNS(apex)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		nameservers = append(nameservers, strings.TrimSuffix(record.Host, "."))
+	}
+	return nameservers, nil
+}
+
+// findZoneApex walks up domain's label tree, querying each ancestor for an
+// SOA record until one answers, the same technique lego and other ACME
+// clients use to locate a zone apex since the exact common name on a
+// certificate is very often a subdomain rather than the apex itself.
+func findZoneApex(domain string) (string, error) {
+	labels := strings.Split(strings.TrimSuffix(domain, "."), ".")
+	for i := 0; i < len(labels)-1; i++ {
+		candidate := strings.Join(labels[i:], ".")
+		if hasSOA(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no SOA record found for %s or any parent domain", domain)
+}
+
+// hasSOA reports whether domain has an SOA record, using the system's
+// configured resolver directly since Go's net package exposes no SOA
+// lookup.
+func hasSOA(domain string) bool {
+	conf, err := miekgdns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil || len(conf.Servers) == 0 {
+		return false
+	}
+
+	msg := new(miekgdns.Msg)
+	msg.SetQuestion(miekgdns.Fqdn(domain), miekgdns.TypeSOA)
+
+	client := &miekgdns.Client{Timeout: 5 * time.Second}
+	addr := net.JoinHostPort(conf.Servers[0], conf.Port)
+	resp, _, err := client.Exchange(msg, addr)
+	if err != nil || resp == nil {
+		return false
+	}
+
+	for _, answer := range resp.Answer {
+		if _, ok := answer.(*miekgdns.SOA); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// queryTXTRecord asks nameserver directly for domain's TXT records over
+// UDP, falling back to TCP if the response is truncated, and reports
+// whether any record contains token.
+func queryTXTRecord(nameserver, domain, token string) (bool, error) {
+	msg := new(miekgdns.Msg)
+	msg.SetQuestion(miekgdns.Fqdn(domain), miekgdns.TypeTXT)
+
+	client := &miekgdns.Client{Net: "udp", Timeout: 10 * time.Second}
+	addr := net.JoinHostPort(nameserver, "53")
+	resp, _, err := client.Exchange(msg, addr)
+	if err == nil && resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.Exchange(msg, addr)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, answer := range resp.Answer {
+		txt, ok := answer.(*miekgdns.TXT)
+		if !ok {
+			continue
+		}
+		for _, s := range txt.Txt {
+			if strings.Contains(s, token) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+
+package dns
+
+import "fmt"
+
+// RecordName joins a record's relative name (rr) with its parent domain,
+// the way every DNSProvider implementation needs to when a backend
+// addresses records by fully-qualified name rather than by ID. rr of ""
+// or "@" refers to the zone apex itself.
+func RecordName(domain, rr string) string {
+	if rr == "" || rr == "@" {
+		return domain
+	}
+	return fmt.Sprintf("%s.%s", rr, domain)
+}
+
+// Record is a normalized DNS record shape shared by every DNSProvider
+// implementation, so callers never need to know which backend they are
+// talking to.
+type Record struct {
+	ID    string
+	Name  string
+	Type  string
+	Value string
+}
+
+// DNSProvider is implemented by every DNS backend that DCV can create
+// verification records against. Concrete implementations live under
+// digicert/dns/platform/<provider>.
+type DNSProvider interface {
+	GetAllDnsRecords(domain string) ([]Record, error)
+	AddDnsRecord(domain, rrType, rr, value string) (recordID string, err error)
+	UpdateDnsRecord(id, rrType, subdomain, value string) (err error)
+	// DeleteDnsRecord deletes the record identified by id. rrType is
+	// required because backends without a native record-ID concept
+	// (Route53, Cloud DNS) address records by name and would otherwise
+	// have to guess the type, risking deleting an unrelated record that
+	// happens to share the name.
+	DeleteDnsRecord(id, rrType string) (err error)
+	CreateVerificationRecord(commonName, token string) (recordID string, err error)
+}
+
+// Factory builds a DNSProvider from the provider-specific config supplied by
+// the Terraform resource's dns_provider block.
+type Factory func(config map[string]string) (DNSProvider, error)
+
+var providers = map[string]Factory{}
+
+// Register makes a DNSProvider factory available under name. It is meant to
+// be called from the init() of each platform package.
+func Register(name string, factory Factory) {
+	providers[name] = factory
+}
+
+// Get instantiates the DNSProvider registered under name.
+func Get(name string, config map[string]string) (DNSProvider, error) {
+	factory, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("dns.Get(): unsupported dns_provider %q", name)
+	}
+	return factory(config)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+
+package keysource
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+func init() {
+	Register("inmem", newInmem)
+}
+
+// inmem generates the private key in-process, matching the resource's
+// existing behavior before key_source was introduced.
+type inmem struct {
+	signatureHash string
+	signer        crypto.Signer
+}
+
+func newInmem(config map[string]string) (KeySource, error) {
+	return &inmem{signatureHash: config["signature_hash"]}, nil
+}
+
+func (k *inmem) Generate() (crypto.Signer, error) {
+	switch k.signatureHash {
+	case "", "sha256":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("keysource.inmem: failed to generate RSA key: %w", err)
+		}
+		k.signer = key
+	case "ecdsa256":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("keysource.inmem: failed to generate ECDSA key: %w", err)
+		}
+		k.signer = key
+	default:
+		return nil, fmt.Errorf("keysource.inmem: unsupported signature_hash %q", k.signatureHash)
+	}
+	return k.signer, nil
+}
+
+// Handle returns "" since an in-memory key has no external identity; the
+// caller stores the PEM-encoded key itself, as it always has.
+func (k *inmem) Handle() string {
+	return ""
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Package keysource abstracts where a certificate resource's private key
+// lives. The default source generates an in-memory RSA/ECDSA key, the same
+// way the resource always has; pkcs11 and kms sources generate the key on
+// an HSM or a cloud KMS instead, so the raw private key never needs to
+// enter Terraform state.
+package keysource
+
+import "crypto"
+
+// KeySource produces a crypto.Signer usable with digicertapi.BuildCSR, and
+// a Handle identifying where the key lives so it can be persisted to state
+// in place of a PEM-encoded private key.
+type KeySource interface {
+	// Generate creates (or opens) the keypair and returns a Signer backed
+	// by it.
+	Generate() (crypto.Signer, error)
+	// Handle returns an opaque identifier for the key (e.g. a PKCS#11
+	// label or a KMS key URI) suitable for storing in Terraform state.
+	Handle() string
+}
+
+// Closer is implemented by KeySources that hold an open handle (e.g. a
+// PKCS#11 session) that must be released once the caller is done signing
+// with it. Callers should type-assert for this after use:
+//
+//	if closer, ok := src.(keysource.Closer); ok {
+//		defer closer.Close()
+//	}
+type Closer interface {
+	Close() error
+}
+
+// Factory builds a KeySource from the config block's attributes.
+type Factory func(config map[string]string) (KeySource, error)
+
+var sources = map[string]Factory{}
+
+// Register makes a KeySource factory available under name. It is meant to
+// be called from the init() of each key_source implementation.
+func Register(name string, factory Factory) {
+	sources[name] = factory
+}
+
+// Get instantiates the KeySource registered under name. name is empty for
+// the default in-memory source.
+func Get(name string, config map[string]string) (KeySource, error) {
+	if name == "" {
+		name = "inmem"
+	}
+	factory, ok := sources[name]
+	if !ok {
+		return nil, errUnsupported(name)
+	}
+	return factory(config)
+}
+
+type errUnsupported string
+
+func (e errUnsupported) Error() string {
+	return "keysource.Get(): unsupported key_source " + string(e)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Package kms generates and signs with a private key held by a cloud KMS,
+// for the certificate resource's key_source.kms block. It follows the
+// go-kms-wrapping convention of addressing keys by a "<provider>://" URI.
+package kms
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/myklst/terraform-provider-st-digicert/digicert/keysource"
+)
+
+func init() {
+	keysource.Register("kms", newSource)
+}
+
+type source struct {
+	keyURI string
+}
+
+func newSource(config map[string]string) (keysource.KeySource, error) {
+	if config["key_uri"] == "" {
+		return nil, fmt.Errorf("keysource/kms: missing key_uri")
+	}
+	return &source{keyURI: config["key_uri"]}, nil
+}
+
+// Generate binds to the pre-existing asymmetric signing key identified by
+// s.keyURI and returns a crypto.Signer that delegates Sign calls back to
+// the KMS, so the private key material never leaves it. It does not create
+// a key; the key_uri is expected to reference one provisioned ahead of
+// time (e.g. via the cloud provider's own KMS resource).
+func (s *source) Generate() (crypto.Signer, error) {
+	signer, err := newKMSSigner(s.keyURI)
+	if err != nil {
+		return nil, fmt.Errorf("keysource/kms: failed to bind to signing key: %w", err)
+	}
+	return signer, nil
+}
+
+// Handle identifies the key for storage in Terraform state in place of a
+// raw private key.
+func (s *source) Handle() string {
+	return s.keyURI
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Package pkcs11 generates and signs with a private key that never leaves
+// an HSM, for the certificate resource's key_source.pkcs11 block.
+package pkcs11
+
+import (
+	"crypto"
+	"fmt"
+	"strconv"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/keysource"
+)
+
+func init() {
+	keysource.Register("pkcs11", newSource)
+}
+
+type source struct {
+	modulePath string
+	slot       int
+	pin        string
+	label      string
+	ctx        *crypto11.Context
+}
+
+func newSource(config map[string]string) (keysource.KeySource, error) {
+	if config["module_path"] == "" {
+		return nil, fmt.Errorf("keysource/pkcs11: missing module_path")
+	}
+	if config["label"] == "" {
+		return nil, fmt.Errorf("keysource/pkcs11: missing label")
+	}
+
+	slot, err := strconv.Atoi(config["slot"])
+	if err != nil {
+		return nil, fmt.Errorf("keysource/pkcs11: invalid slot %q: %w", config["slot"], err)
+	}
+
+	return &source{
+		modulePath: config["module_path"],
+		slot:       slot,
+		pin:        config["pin"],
+		label:      config["label"],
+	}, nil
+}
+
+// Generate opens a session against the configured PKCS#11 module and
+// generates an RSA-2048 keypair under s.label, returning a crypto.Signer
+// backed by the HSM-resident private key.
+func (s *source) Generate() (crypto.Signer, error) {
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       s.modulePath,
+		SlotNumber: &s.slot,
+		Pin:        s.pin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("keysource/pkcs11: failed to open module: %w", err)
+	}
+	s.ctx = ctx
+
+	signer, err := ctx.GenerateRSAKeyPairWithLabel([]byte(s.label), []byte(s.label), 2048)
+	if err != nil {
+		return nil, fmt.Errorf("keysource/pkcs11: failed to generate keypair: %w", err)
+	}
+
+	return signer, nil
+}
+
+// Handle identifies the key for storage in Terraform state in place of a
+// raw private key.
+func (s *source) Handle() string {
+	return fmt.Sprintf("pkcs11:module=%s;slot=%d;label=%s", s.modulePath, s.slot, s.label)
+}
+
+// Close releases the PKCS#11 session opened by Generate. It satisfies
+// keysource.Closer; callers must invoke it once they are done signing,
+// otherwise every CSR generated through this key source leaks a session.
+func (s *source) Close() error {
+	if s.ctx == nil {
+		return nil
+	}
+	return s.ctx.Close()
+}
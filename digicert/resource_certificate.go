@@ -0,0 +1,456 @@
+// Copyright (c) HashiCorp, Inc.
+
+package digicert
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/myklst/terraform-provider-st-digicert/acmeapi"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+	_ "github.com/myklst/terraform-provider-st-digicert/digicert/dns/platform/alicloud"
+	_ "github.com/myklst/terraform-provider-st-digicert/digicert/dns/platform/cloudflare"
+	_ "github.com/myklst/terraform-provider-st-digicert/digicert/dns/platform/gcloud"
+	_ "github.com/myklst/terraform-provider-st-digicert/digicert/dns/platform/route53"
+	"github.com/myklst/terraform-provider-st-digicert/digicert/keysource"
+	_ "github.com/myklst/terraform-provider-st-digicert/digicert/keysource/kms"
+	_ "github.com/myklst/terraform-provider-st-digicert/digicert/keysource/pkcs11"
+	digicertapi "github.com/myklst/terraform-provider-st-digicert/digicertAPI"
+)
+
+// The blank imports above register every dns.DNSProvider and
+// keysource.KeySource implementation via their init() functions, so the
+// dns.Get/keysource.Get lookups below actually have something to find.
+
+var (
+	_ resource.Resource              = &certificateResource{}
+	_ resource.ResourceWithConfigure = &certificateResource{}
+)
+
+func NewCertificateResource() resource.Resource {
+	return &certificateResource{}
+}
+
+// certificateResource issues a DigiCert certificate, either through
+// DigiCert's own REST API or through any RFC 8555 ACME CA, using a DNS-01
+// challenge dispatched through a pluggable dns.DNSProvider.
+type certificateResource struct {
+	client *digicertapi.Client
+}
+
+type certificateResourceModel struct {
+	ID                    types.String     `tfsdk:"id"`
+	CommonName            types.String     `tfsdk:"common_name"`
+	DNSNames              types.List       `tfsdk:"dns_names"`
+	IPSans                types.List       `tfsdk:"ip_sans"`
+	URISans               types.List       `tfsdk:"uri_sans"`
+	ExtraNames            []extraNameModel `tfsdk:"extra_names"`
+	PreferredChain        types.String     `tfsdk:"preferred_chain"`
+	DNSProvider           dnsProviderModel `tfsdk:"dns_provider"`
+	KeySource             *keySourceModel  `tfsdk:"key_source"`
+	CABackend             types.String     `tfsdk:"ca_backend"`
+	ACMEDirectoryURL      types.String     `tfsdk:"acme_directory_url"`
+	ACMEEABKeyID          types.String     `tfsdk:"acme_eab_key_id"`
+	ACMEEABHMACKey        types.String     `tfsdk:"acme_eab_hmac_key"`
+	DNSPropagationTimeout types.String     `tfsdk:"dns_propagation_timeout"`
+	CertificateChain      types.String     `tfsdk:"certificate_chain"`
+	PrivateKey            types.String     `tfsdk:"private_key"`
+	PrivateKeyHandle      types.String     `tfsdk:"private_key_handle"`
+}
+
+type extraNameModel struct {
+	OID   types.String `tfsdk:"oid"`
+	Value types.String `tfsdk:"value"`
+}
+
+type dnsProviderModel struct {
+	Name   types.String `tfsdk:"name"`
+	Config types.Map    `tfsdk:"config"`
+}
+
+type keySourceModel struct {
+	Type       types.String `tfsdk:"type"`
+	ModulePath types.String `tfsdk:"module_path"`
+	Slot       types.String `tfsdk:"slot"`
+	Pin        types.String `tfsdk:"pin"`
+	Label      types.String `tfsdk:"label"`
+	KeyURI     types.String `tfsdk:"key_uri"`
+}
+
+func (r *certificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+func (r *certificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*digicertapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *digicertapi.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *certificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a DigiCert certificate, issued either through the DigiCert REST API or through any RFC 8555 ACME CA.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"common_name": schema.StringAttribute{
+				Required: true,
+			},
+			"dns_names": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"ip_sans": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "IP addresses added to the CSR's SAN extension via BuildCSR.",
+			},
+			"uri_sans": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "URIs added to the CSR's SAN extension via BuildCSR.",
+			},
+			"extra_names": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Arbitrary Subject RDNs written into the CSR via BuildCSR.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"oid":   schema.StringAttribute{Required: true},
+						"value": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"preferred_chain": schema.StringAttribute{
+				Optional:    true,
+				Description: "Subject or issuer common name of the root/intermediate to pin, resolved via SelectPreferredChain.",
+			},
+			"ca_backend": schema.StringAttribute{
+				Optional:    true,
+				Description: `Either "digicert" (default) or "acme".`,
+			},
+			"acme_directory_url": schema.StringAttribute{
+				Optional: true,
+			},
+			"acme_eab_key_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"acme_eab_hmac_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"dns_propagation_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: `Duration string (e.g. "2m") passed to WaitForDNSPropagation before DCV is checked.`,
+			},
+			"dns_provider": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: `Selects and configures the dns.DNSProvider backend (name is one of "alicloud", "route53", "cloudflare", "gcloud").`,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{Required: true},
+					"config": schema.MapAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+						Sensitive:   true,
+					},
+				},
+			},
+			"key_source": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: `Selects and configures the keysource.KeySource backend ("pkcs11" or "kms"). Left unset, the key is generated in-process as before.`,
+				Attributes: map[string]schema.Attribute{
+					"type":        schema.StringAttribute{Required: true},
+					"module_path": schema.StringAttribute{Optional: true},
+					"slot":        schema.StringAttribute{Optional: true},
+					"pin":         schema.StringAttribute{Optional: true, Sensitive: true},
+					"label":       schema.StringAttribute{Optional: true},
+					"key_uri":     schema.StringAttribute{Optional: true},
+				},
+			},
+			"certificate_chain": schema.StringAttribute{
+				Computed: true,
+			},
+			"private_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded private key, set only when key_source is left unset (the default in-memory source). Empty for pkcs11/kms, whose key material never leaves the HSM/KMS.",
+			},
+			"private_key_handle": schema.StringAttribute{
+				Computed:    true,
+				Description: "Empty for an in-process key; otherwise the key_source Handle() (PKCS#11 URI or KMS key URI) so the raw key never enters state.",
+			},
+		},
+	}
+}
+
+func (r *certificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan certificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dnsProvider, err := r.resolveDNSProvider(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure DNS provider", err.Error())
+		return
+	}
+
+	signer, keySourceHandle, keyPEM, closeKeySource, err := r.resolveSigner(plan.KeySource)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate private key", err.Error())
+		return
+	}
+	defer closeKeySource()
+
+	payload := digicertapi.CertificatePayload{
+		CommonName: plan.CommonName.ValueString(),
+		DNSNames:   stringListOrEmpty(plan.DNSNames),
+		IPSans:     stringListOrEmpty(plan.IPSans),
+		URISans:    stringListOrEmpty(plan.URISans),
+	}
+	for _, extra := range plan.ExtraNames {
+		payload.ExtraNames = append(payload.ExtraNames, digicertapi.ExtraName{
+			OID:   extra.OID.ValueString(),
+			Value: extra.Value.ValueString(),
+		})
+	}
+
+	csrPEM, err := digicertapi.BuildCSR(payload, signer)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build CSR", err.Error())
+		return
+	}
+	payload.CSR = csrPEM
+
+	// Only the DigiCert order payload's dns_names gets the IP/URI SANs
+	// folded in as strings; the CSR itself carries them in their proper
+	// IPAddresses/URIs SAN fields, set above by BuildCSR.
+	if plan.CABackend.ValueString() != "acme" {
+		payload.DNSNames = digicertapi.AugmentedDNSNames(payload)
+	}
+
+	authority, err := r.resolveCertAuthority(plan, dnsProvider)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure certificate authority", err.Error())
+		return
+	}
+
+	issued, err := authority.IssueCert(digicertapi.OrderPayload{Certificate: payload})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to issue certificate", err.Error())
+		return
+	}
+
+	if err := r.validateDomains(dnsProvider, plan, issued); err != nil {
+		resp.Diagnostics.AddError("Unable to complete domain control validation", err.Error())
+		return
+	}
+
+	chain, err := r.selectChain(plan, issued, resp)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to select certificate chain", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%d", issued.CertificateID))
+	plan.CertificateChain = types.StringValue(chainToPEM(chain))
+	plan.PrivateKey = types.StringValue(keyPEM)
+	plan.PrivateKeyHandle = types.StringValue(keySourceHandle)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *certificateResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {}
+
+func (r *certificateResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
+}
+
+func (r *certificateResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
+
+func (r *certificateResource) resolveDNSProvider(plan certificateResourceModel) (dns.DNSProvider, error) {
+	config := map[string]string{}
+	if !plan.DNSProvider.Config.IsNull() {
+		for k, v := range plan.DNSProvider.Config.Elements() {
+			if s, ok := v.(types.String); ok {
+				config[k] = s.ValueString()
+			}
+		}
+	}
+	return dns.Get(plan.DNSProvider.Name.ValueString(), config)
+}
+
+// resolveSigner builds the crypto.Signer used to sign the CSR, either from
+// the configured key_source (PKCS#11/KMS) or, if unset, from the default
+// in-memory source (keysource.Get("", ...)). It returns the Handle and PEM
+// key to persist to state, and a close func that releases the key source
+// once the caller is done signing, if it implements keysource.Closer.
+//
+// Handle is empty for the in-memory source, so keyPEM is populated instead
+// (the only place the key exists); for pkcs11/kms, Handle identifies the
+// external key and keyPEM stays empty, since that key material never
+// leaves the HSM/KMS.
+func (r *certificateResource) resolveSigner(model *keySourceModel) (signer crypto.Signer, handle string, keyPEM string, closeFn func(), err error) {
+	config := map[string]string{}
+	sourceType := ""
+	if model != nil {
+		sourceType = model.Type.ValueString()
+		config["module_path"] = model.ModulePath.ValueString()
+		config["slot"] = model.Slot.ValueString()
+		config["pin"] = model.Pin.ValueString()
+		config["label"] = model.Label.ValueString()
+		config["key_uri"] = model.KeyURI.ValueString()
+	}
+
+	src, err := keysource.Get(sourceType, config)
+	if err != nil {
+		return nil, "", "", func() {}, err
+	}
+
+	signer, err = src.Generate()
+	if err != nil {
+		return nil, "", "", func() {}, err
+	}
+
+	closeFn = func() {}
+	if closer, ok := src.(keysource.Closer); ok {
+		closeFn = func() {
+			if err := closer.Close(); err != nil {
+				tflog.Warn(context.Background(), fmt.Sprintf("failed to close key source: %s", err))
+			}
+		}
+	}
+
+	handle = src.Handle()
+	if handle == "" {
+		keyPEM, err = marshalPrivateKeyPEM(signer)
+		if err != nil {
+			return nil, "", "", closeFn, err
+		}
+	}
+
+	return signer, handle, keyPEM, closeFn, nil
+}
+
+// marshalPrivateKeyPEM PEM-encodes signer's private key as PKCS#8, for
+// storing the default in-memory key_source's key in Terraform state the
+// same way the resource always has.
+func marshalPrivateKeyPEM(signer crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+func (r *certificateResource) resolveCertAuthority(plan certificateResourceModel, dnsProvider dns.DNSProvider) (digicertapi.CertAuthority, error) {
+	if plan.CABackend.ValueString() != "acme" {
+		return r.client, nil
+	}
+
+	return acmeapi.NewClient(acmeapi.Config{
+		DirectoryURL: plan.ACMEDirectoryURL.ValueString(),
+		EABKeyID:     plan.ACMEEABKeyID.ValueString(),
+		EABHMACKey:   plan.ACMEEABHMACKey.ValueString(),
+		DNSProvider:  dnsProvider,
+	})
+}
+
+// validateDomains publishes the DCV verification record for every domain
+// DigiCert returned on the order and waits for it to propagate before
+// asking DigiCert to check it, so the CA's resolver doesn't race ahead of
+// the DNS provider's authoritative nameservers.
+func (r *certificateResource) validateDomains(dnsProvider dns.DNSProvider, plan certificateResourceModel, issued digicertapi.IssueCertRespBody) error {
+	var propagationTimeout time.Duration
+	if raw := plan.DNSPropagationTimeout.ValueString(); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid dns_propagation_timeout %q: %w", raw, err)
+		}
+		propagationTimeout = parsed
+	}
+
+	for _, domain := range issued.Domains {
+		if _, err := dnsProvider.CreateVerificationRecord(domain.Name, domain.DcvToken.Token); err != nil {
+			return fmt.Errorf("failed to publish verification record for %s: %w", domain.Name, err)
+		}
+		if err := r.client.CheckDomainDCVWithPropagation(domain.ID, domain.Name, domain.DcvToken.Token, propagationTimeout); err != nil {
+			return fmt.Errorf("failed to validate %s: %w", domain.Name, err)
+		}
+	}
+	return nil
+}
+
+// selectChain resolves the candidate chains to pick preferred_chain from.
+// The ACME backend already downloaded its chain as part of IssueCert
+// (issued.CertificateChain); issued.CertificateID is never set on that
+// path, so GetCertificateChains (a DigiCert REST call) must only be used
+// for the digicert backend.
+func (r *certificateResource) selectChain(plan certificateResourceModel, issued digicertapi.IssueCertRespBody, resp *resource.CreateResponse) ([]digicertapi.CertificateChain, error) {
+	preferredChain := plan.PreferredChain.ValueString()
+
+	var chains [][]digicertapi.CertificateChain
+	if plan.CABackend.ValueString() == "acme" {
+		chains = [][]digicertapi.CertificateChain{issued.CertificateChain}
+	} else {
+		fetched, err := r.client.GetCertificateChains(issued.CertificateID)
+		if err != nil {
+			return nil, err
+		}
+		chains = fetched
+	}
+
+	selected, matched, err := digicertapi.SelectPreferredChain(chains, preferredChain)
+	if err != nil {
+		return nil, err
+	}
+	if preferredChain != "" && !matched {
+		resp.Diagnostics.AddWarning(
+			"Preferred chain not found",
+			fmt.Sprintf("No candidate chain matched preferred_chain %q; falling back to the default chain.", preferredChain),
+		)
+	}
+	return selected, nil
+}
+
+func chainToPEM(chain []digicertapi.CertificateChain) string {
+	var pem string
+	for _, link := range chain {
+		pem += link.Pem
+	}
+	return pem
+}
+
+func stringListOrEmpty(list types.List) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	values := make([]string, 0, len(list.Elements()))
+	for _, elem := range list.Elements() {
+		if s, ok := elem.(types.String); ok {
+			values = append(values, s.ValueString())
+		}
+	}
+	return values
+}
@@ -42,6 +42,15 @@ type CertificatePayload struct {
 	CertificateChain []CertificateChain `json:"certificate_chain"`
 	Error            []ErrorMsg         `json:"errors"`
 	PrivateKey       string             `json:"-"`
+	// PreferredChain is not sent to DigiCert. SelectPreferredChain takes
+	// the preferred name as its own argument and does not read this field.
+	PreferredChain string `json:"-"`
+	// ExtraNames, IPSans and URISans are consumed by BuildCSR and are not
+	// sent to DigiCert directly; AugmentedDNSNames folds IPSans/URISans
+	// into the dns_names order attribute.
+	ExtraNames []ExtraName `json:"-"`
+	IPSans     []string    `json:"-"`
+	URISans    []string    `json:"-"`
 }
 
 type DomainPayload struct {
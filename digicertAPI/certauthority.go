@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+
+package digicertapi
+
+// CertAuthority abstracts the certificate lifecycle operations the
+// Terraform resource needs, so the same resource can be backed either by
+// DigiCert's REST API (*Client, the default) or by any RFC 8555 ACME CA
+// implementing this interface, such as acmeapi.Client.
+type CertAuthority interface {
+	IssueCert(orderPayload OrderPayload) (IssueCertRespBody, error)
+	ReissueCert(orderPayload OrderPayload, orderID int) (IssueCertRespBody, error)
+	RevokeCert(certID int) error
+}
+
+var _ CertAuthority = (*Client)(nil)
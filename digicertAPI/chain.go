@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+
+package digicertapi
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+)
+
+// GetCertificateChains behaves like GetCertificateChain, but additionally
+// fetches DigiCert's alternate chain (e.g. a cross-signed root next to a
+// self-signed one) so callers can choose between them via
+// SelectPreferredChain. Each returned slice is a full candidate chain in
+// leaf-to-root order.
+func (c *Client) GetCertificateChains(certID int) (chains [][]CertificateChain, err error) {
+	primary, err := c.GetCertificateChain(certID)
+	if err != nil {
+		return nil, err
+	}
+	chains = append(chains, primary)
+
+	alternateURL := fmt.Sprintf("%s/%d/chain?alternative=true", CERT_ENDPOINT, certID)
+	resp, err := c.httpResponse(http.MethodGet, alternateURL, nil)
+	if err != nil {
+		// Not every product offers an alternate chain; treat failure to
+		// fetch one as "no alternate available" rather than an error.
+		return chains, nil
+	}
+
+	var alternateList CertificateChainList
+	if err := json.Unmarshal(resp, &alternateList); err != nil {
+		return chains, nil
+	}
+	if len(alternateList.CertificateChain) != 0 {
+		chains = append(chains, alternateList.CertificateChain)
+	}
+
+	return chains, nil
+}
+
+// SelectPreferredChain walks each candidate chain and returns the first one
+// where any certificate's subject or issuer common name matches
+// preferredChain. If nothing matches, it returns the first (default) chain
+// with matched=false so callers can fall back and warn.
+func SelectPreferredChain(chains [][]CertificateChain, preferredChain string) (selected []CertificateChain, matched bool, err error) {
+	if len(chains) == 0 {
+		return nil, false, fmt.Errorf("digicertapi.SelectPreferredChain(): no candidate chains supplied")
+	}
+	if preferredChain == "" {
+		return chains[0], true, nil
+	}
+
+	for _, chain := range chains {
+		for _, link := range chain {
+			block, _ := pem.Decode([]byte(link.Pem))
+			if block == nil {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			if cert.Subject.CommonName == preferredChain || cert.Issuer.CommonName == preferredChain {
+				return chain, true, nil
+			}
+		}
+	}
+
+	return chains[0], false, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+
+package digicertapi
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ExtraName is an arbitrary Subject RDN, keyed by dotted OID, that some
+// legacy PKIs require but DigiCert's own UI has no field for (e.g.
+// "1.2.840.113549.1.9.1" for an emailAddress RDN).
+type ExtraName struct {
+	OID   string `json:"oid"`
+	Value string `json:"value"`
+}
+
+// BuildCSR generates a PKCS#10 certificate request for payload, signed by
+// key. It threads payload.ExtraNames into the Subject as raw RDNs and
+// payload.IPSans/payload.URISans into the request's SAN extension
+// alongside payload.DNSNames.
+func BuildCSR(payload CertificatePayload, key crypto.Signer) (csrPEM string, err error) {
+	subject := pkix.Name{CommonName: payload.CommonName}
+	for _, extra := range payload.ExtraNames {
+		oid, err := parseOID(extra.OID)
+		if err != nil {
+			return "", fmt.Errorf("digicertapi.BuildCSR(): invalid extra_names oid %q: %w", extra.OID, err)
+		}
+		subject.ExtraNames = append(subject.ExtraNames, pkix.AttributeTypeAndValue{
+			Type:  oid,
+			Value: extra.Value,
+		})
+	}
+
+	ipSans := make([]net.IP, 0, len(payload.IPSans))
+	for _, raw := range payload.IPSans {
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			return "", fmt.Errorf("digicertapi.BuildCSR(): invalid ip_sans entry %q", raw)
+		}
+		ipSans = append(ipSans, ip)
+	}
+
+	uriSans := make([]*url.URL, 0, len(payload.URISans))
+	for _, raw := range payload.URISans {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", fmt.Errorf("digicertapi.BuildCSR(): invalid uri_sans entry %q: %w", raw, err)
+		}
+		uriSans = append(uriSans, u)
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:            subject,
+		DNSNames:           payload.DNSNames,
+		IPAddresses:        ipSans,
+		URIs:               uriSans,
+		SignatureAlgorithm: signatureAlgorithmFor(key.Public()),
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return "", fmt.Errorf("digicertapi.BuildCSR(): failed to create CSR: %w", err)
+	}
+
+	block := &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// AugmentedDNSNames returns payload.DNSNames with the IP and URI SANs
+// appended as strings, matching the shape DigiCert's dns_names order
+// attribute expects when a certificate carries non-DNS SANs.
+func AugmentedDNSNames(payload CertificatePayload) []string {
+	names := make([]string, 0, len(payload.DNSNames)+len(payload.IPSans)+len(payload.URISans))
+	names = append(names, payload.DNSNames...)
+	names = append(names, payload.IPSans...)
+	names = append(names, payload.URISans...)
+	return names
+}
+
+// signatureAlgorithmFor picks a SignatureAlgorithm matching pub's key type,
+// since x509.CreateCertificateRequest rejects an explicit
+// SignatureAlgorithm that doesn't match the signer (e.g. SHA256WithRSA
+// against an ECDSA key), and the key sources BuildCSR is used with are not
+// all RSA.
+func signatureAlgorithmFor(pub crypto.PublicKey) x509.SignatureAlgorithm {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		switch key.Curve.Params().BitSize {
+		case 384:
+			return x509.ECDSAWithSHA384
+		case 521:
+			return x509.ECDSAWithSHA512
+		default:
+			return x509.ECDSAWithSHA256
+		}
+	case ed25519.PublicKey:
+		return x509.PureEd25519
+	default:
+		// Let x509.CreateCertificateRequest pick its own default rather
+		// than guess for a key type we don't recognize.
+		return x509.UnknownSignatureAlgorithm
+	}
+}
+
+func parseOID(raw string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(raw, ".")
+	oid := make(asn1.ObjectIdentifier, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		oid[i] = n
+	}
+	return oid, nil
+}
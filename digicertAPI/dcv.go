@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+
+package digicertapi
+
+import (
+	"time"
+
+	"github.com/myklst/terraform-provider-st-digicert/digicert/dns"
+)
+
+// CheckDomainDCVWithPropagation waits for the DNS-01 verification record
+// to propagate to every authoritative nameserver of domain before calling
+// CheckDomainDCV, so DigiCert's resolver doesn't validate against a stale
+// (or absent) record and force an avoidable retry. propagationTimeout of
+// zero falls back to dns.DefaultPropagationTimeout.
+func (c *Client) CheckDomainDCVWithPropagation(domainID int, domain, token string, propagationTimeout time.Duration) error {
+	if err := dns.WaitForDNSPropagation(domain, token, propagationTimeout); err != nil {
+		return err
+	}
+	return c.CheckDomainDCV(domainID)
+}